@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+)
+
+const exceptionDateFormat = "2006-01-02"
+
+func policyCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Manage local Infracost policy configuration",
+	}
+
+	cmd.AddCommand(policyExceptionCmd(ctx))
+
+	return cmd
+}
+
+func policyExceptionCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exception",
+		Short: "Manage local policy exceptions (waivers)",
+		Long: `Manage local policy exceptions (waivers).
+
+Exceptions let you exclude a specific resource from a specific policy rule
+without disabling the rule for everyone else. They are stored in
+.infracost/exceptions.yaml and are honoured by both local and Infracost
+Cloud policy evaluation.`,
+	}
+
+	cmd.AddCommand(policyExceptionAddCmd(ctx))
+	cmd.AddCommand(policyExceptionListCmd(ctx))
+	cmd.AddCommand(policyExceptionRemoveCmd(ctx))
+
+	return cmd
+}
+
+func policyExceptionAddCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a policy exception",
+		Example: `  infracost policy exception add \
+      --rule-id can-use-spot \
+      --address aws_instance.web \
+      --reason "Migrating to spot instances next sprint" \
+      --expiry 2026-09-01`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checksum, _ := cmd.Flags().GetString("checksum")
+			address, _ := cmd.Flags().GetString("address")
+			pathGlob, _ := cmd.Flags().GetString("path")
+			ruleID, _ := cmd.Flags().GetString("rule-id")
+			reason, _ := cmd.Flags().GetString("reason")
+			expiryStr, _ := cmd.Flags().GetString("expiry")
+
+			if checksum == "" && address == "" && pathGlob == "" {
+				return fmt.Errorf("one of --checksum, --address or --path must be set")
+			}
+
+			var expiry *time.Time
+			if expiryStr != "" {
+				t, err := time.Parse(exceptionDateFormat, expiryStr)
+				if err != nil {
+					return fmt.Errorf("invalid --expiry %q, expected format %s: %w", expiryStr, exceptionDateFormat, err)
+				}
+				expiry = &t
+			}
+
+			store, err := apiclient.LoadExceptionStore(ctx.Config.ExceptionsFile())
+			if err != nil {
+				return err
+			}
+
+			e := apiclient.Exception{
+				Ref:      apiclient.ExceptionRef(address, checksum, pathGlob, ruleID),
+				Checksum: checksum,
+				Address:  address,
+				PathGlob: pathGlob,
+				RuleID:   ruleID,
+				Reason:   reason,
+				Expiry:   expiry,
+			}
+
+			if err := store.Add(e); err != nil {
+				return fmt.Errorf("could not save exception %w", err)
+			}
+
+			cmd.Printf("Added exception %s\n", e.Ref)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("checksum", "", "Resource checksum to match, as reported in a FinOps policy violation")
+	cmd.Flags().String("address", "", "Resource address to match, e.g. aws_instance.web")
+	cmd.Flags().String("path", "", "Glob to match the resource's file path, e.g. 'modules/networking/*'")
+	cmd.Flags().String("rule-id", "", "Policy rule id (TagPolicy or FinOpsPolicy id) to waive for the matched resource(s)")
+	cmd.Flags().String("reason", "", "Reason for the exception, shown in `infracost policy exception list`")
+	cmd.Flags().String("expiry", "", "Date the exception stops applying, format "+exceptionDateFormat)
+
+	_ = cmd.MarkFlagRequired("rule-id")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+func policyExceptionListCmd(ctx *config.RunContext) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List policy exceptions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := apiclient.LoadExceptionStore(ctx.Config.ExceptionsFile())
+			if err != nil {
+				return err
+			}
+
+			if len(store.Exceptions) == 0 {
+				cmd.Println("No policy exceptions found.")
+				return nil
+			}
+
+			now := time.Now()
+			for _, e := range store.Exceptions {
+				status := "active"
+				if e.Expiry != nil && now.After(*e.Expiry) {
+					status = "expired"
+				}
+
+				match := e.Address
+				if match == "" {
+					match = e.Checksum
+				}
+				if match == "" {
+					match = e.PathGlob
+				}
+
+				cmd.Printf("%s  rule=%s  match=%s  status=%s  reason=%q\n", e.Ref, e.RuleID, match, status, e.Reason)
+			}
+
+			return nil
+		},
+	}
+}
+
+func policyExceptionRemoveCmd(ctx *config.RunContext) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <ref>",
+		Short: "Remove a policy exception by its ref",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := apiclient.LoadExceptionStore(ctx.Config.ExceptionsFile())
+			if err != nil {
+				return err
+			}
+
+			if err := store.Remove(args[0]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Removed exception %s\n", args[0])
+
+			return nil
+		},
+	}
+}
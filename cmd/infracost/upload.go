@@ -1,22 +1,72 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/robfig/cron/v3"
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/logging"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/runhistory"
 	"github.com/infracost/infracost/internal/ui"
 )
 
+// defaultHistoryFile returns ~/.infracost/uploads.db, the local run history
+// used by --watch, --schedule, `upload history` and `upload retry`.
+func defaultHistoryFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine home directory %w", err)
+	}
+
+	return filepath.Join(home, ".infracost", "uploads.db"), nil
+}
+
+// resolveSinks merges --sink flags with the `sinks:` block in infracost.yml
+// and builds the resulting RunSinks. Infracost Cloud is dropped from the
+// self-hosted case instead of erroring outright, so self-hosted users can
+// still route runs to a sink they control; it's only an error if that
+// leaves no sinks at all.
+func resolveSinks(ctx *config.RunContext, flagSinks []string) ([]apiclient.RunSink, error) {
+	raw := flagSinks
+	if len(raw) == 0 {
+		raw = ctx.Config.Sinks
+	}
+	if len(raw) == 0 {
+		raw = []string{"cloud"}
+	}
+
+	if ctx.Config.IsSelfHosted() {
+		filtered := raw[:0]
+		for _, s := range raw {
+			if s != "cloud" {
+				filtered = append(filtered, s)
+			}
+		}
+		raw = filtered
+
+		if len(raw) == 0 {
+			return nil, fmt.Errorf("Infracost Cloud is part of Infracost's hosted services; self-hosted users must pass a --sink (s3://, gs://, azblob:// or webhook:) to use `infracost upload`")
+		}
+	}
+
+	return apiclient.NewRunSinks(ctx, raw)
+}
+
 func uploadCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "upload",
-		Short: "Upload an Infracost JSON file to Infracost Cloud",
-		Long: `Upload an Infracost JSON file to Infracost Cloud. This is useful if you
+		Short: "Upload Infracost JSON file(s) to Infracost Cloud",
+		Long: `Upload Infracost JSON file(s) to Infracost Cloud. This is useful if you
 do not use 'infracost comment' and instead want to define run metadata,
 such as pull request URL or title, and upload the results manually.
 
@@ -28,53 +78,82 @@ See https://infracost.io/docs/features/cli_commands/#upload-runs`,
 
       infracost diff --path plan.json --format json --out-file infracost.json
 
-      infracost upload --path infracost.json`,
+      infracost upload --path infracost.json
+
+  Re-upload and re-evaluate policies whenever the file changes:
+      infracost upload --path infracost.json --watch
+
+  Re-upload on a schedule, e.g. nightly at 2am:
+      infracost upload --path infracost.json --schedule "0 2 * * *"
+
+  Upload every run from a monorepo CI job in one invocation:
+      infracost upload --path 'runs/*.json' --manifest runs/manifest.yml --continue-on-error`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var err error
+			rawPaths, _ := cmd.Flags().GetStringArray("path")
+			localPolicies, _ := cmd.Flags().GetBool("local-policies")
+			schedule, _ := cmd.Flags().GetString("schedule")
+			watch, _ := cmd.Flags().GetBool("watch")
+			sinkFlags, _ := cmd.Flags().GetStringArray("sink")
+			manifestFile, _ := cmd.Flags().GetString("manifest")
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+			continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
 
-			if ctx.Config.IsSelfHosted() {
-				return fmt.Errorf("Infracost Cloud is part of Infracost's hosted services. Contact hello@infracost.io for help.")
-			}
+			ctx.Config.LocalPolicies = localPolicies
 
-			path, _ := cmd.Flags().GetString("path")
-
-			root, err := output.Load(path)
+			paths, err := resolvePaths(rawPaths)
 			if err != nil {
-				return fmt.Errorf("could not load input file %s err: %w", path, err)
+				return err
 			}
 
-			if ctx.Config.PolicyV2APIEndpoint != "" {
-				policyClient, err := apiclient.NewPolicyAPIClient(ctx)
+			var manifest map[string]manifestEntry
+			if manifestFile != "" {
+				manifest, err = loadManifest(manifestFile)
 				if err != nil {
-					logging.Logger.Err(err).Msg("Failed to initialize policies client")
-				} else {
-					policies, err := policyClient.CheckPolicies(ctx, root)
-					if err != nil {
-						logging.Logger.Err(err).Msg("Failed to check policies")
-					}
-
-					root.TagPolicies = policies.TagPolicies
-					root.FinOpsPolicies = policies.FinOpsPolicies
+					return err
 				}
 			}
 
-			dashboardClient := apiclient.NewDashboardAPIClient(ctx)
-			result, err := dashboardClient.AddRun(ctx, root, apiclient.CommentFormatMarkdownHTML)
+			sinks, err := resolveSinks(ctx, sinkFlags)
 			if err != nil {
-				return fmt.Errorf("failed to upload to Infracost Cloud: %w", err)
+				return err
 			}
 
-			root.RunID, root.ShareURL, root.CloudURL = result.RunID, result.ShareURL, result.CloudURL
+			historyFile, err := defaultHistoryFile()
+			if err != nil {
+				return err
+			}
 
-			if root.ShareURL != "" {
-				cmd.Println("Share this cost estimate: ", ui.LinkString(root.ShareURL))
+			history, err := runhistory.Open(historyFile)
+			if err != nil {
+				return fmt.Errorf("could not open upload history %w", err)
 			}
+			defer history.Close() // nolint:errcheck
 
-			pricingClient := apiclient.GetPricingAPIClient(ctx)
-			err = pricingClient.AddEvent("infracost-upload", ctx.EventEnv())
+			policyClient, err := buildPolicyClient(ctx)
 			if err != nil {
-				logging.Logger.Warn().Err(err).Msg("could not report `infracost-upload` event")
+				return err
+			}
+
+			if schedule != "" || watch {
+				if len(paths) != 1 {
+					return fmt.Errorf("--schedule and --watch require exactly one --path, got %d", len(paths))
+				}
+
+				if schedule != "" {
+					return runScheduled(cmd, ctx, paths[0], history, sinks, policyClient, schedule)
+				}
+
+				return runWatch(cmd, ctx, paths[0], history, sinks, policyClient)
+			}
+
+			if len(paths) > 1 || manifestFile != "" {
+				return runBatch(cmd, ctx, paths, manifest, sinks, history, policyClient, parallelism, continueOnError)
+			}
+
+			result, err := doUpload(cmd, ctx, paths[0], history, sinks, policyClient, false)
+			if err != nil {
+				return err
 			}
 
 			if len(result.GovernanceFailures) > 0 {
@@ -85,9 +164,225 @@ See https://infracost.io/docs/features/cli_commands/#upload-runs`,
 		},
 	}
 
-	cmd.Flags().String("path", "p", "Path to Infracost JSON file.")
+	cmd.Flags().StringArray("path", nil, "Path to an Infracost JSON file, directory of JSON files, or glob pattern. Can be specified multiple times.")
+	cmd.Flags().Bool("local-policies", false, "Evaluate policies from the `policies:` directory in infracost.yml locally instead of using Infracost Cloud")
+	cmd.Flags().String("schedule", "", "Cron expression to re-upload and re-evaluate policies on, e.g. '0 2 * * *'. Runs until interrupted.")
+	cmd.Flags().Bool("watch", false, "Re-upload and re-evaluate policies whenever the file at --path changes. Runs until interrupted.")
+	cmd.Flags().String("manifest", "", "Path to a YAML manifest pairing each --path entry with per-run metadata (pull_request_url, pull_request_title, branch, commit), overriding the env vars.")
+	cmd.Flags().Int("parallelism", 4, "Number of files to upload concurrently when more than one --path is matched.")
+	cmd.Flags().Bool("continue-on-error", false, "Keep uploading the remaining files if one fails, instead of aborting the batch.")
+	cmd.PersistentFlags().StringArray("sink", nil, "Where to upload the run, can be specified multiple times. Defaults to cloud. One of: cloud, s3://bucket/prefix, gs://bucket/prefix, azblob://container/prefix, webhook:<url>")
 
 	_ = cmd.MarkFlagRequired("path")
 	_ = cmd.MarkFlagFilename("path", "json")
+
+	cmd.AddCommand(uploadHistoryCmd(ctx))
+	cmd.AddCommand(uploadRetryCmd(ctx))
+
 	return cmd
 }
+
+// uploadResult is what doUpload returns for a single file, used both to
+// print "Share this cost estimate" for a one-shot upload and to build the
+// aggregated summary table for a batch upload.
+type uploadResult struct {
+	RunID              string
+	ShareURL           string
+	GovernanceFailures output.GovernanceFailures
+}
+
+// buildPolicyClient constructs the single PolicyAPIClient shared by every
+// upload in this invocation, so its allow-list (and local policy files) are
+// only loaded once, no matter how many files --path matches.
+func buildPolicyClient(ctx *config.RunContext) (*apiclient.PolicyAPIClient, error) {
+	if ctx.Config.PolicyV2APIEndpoint == "" && !ctx.Config.LocalPolicies {
+		return nil, nil
+	}
+
+	policyClient, err := apiclient.NewPolicyAPIClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize policies client %w", err)
+	}
+
+	return policyClient, nil
+}
+
+// doUpload performs a single upload of the file at path: it evaluates
+// policies using the shared policyClient (nil if policies aren't
+// configured), uploads to every sink and records the run in history.
+//
+// If dedup is true and the file is unchanged since a previous successful
+// run, doUpload skips re-uploading. This is only safe for --watch/
+// --schedule, which call doUpload repeatedly on the same path and rely on
+// it to avoid spamming every sink when the input hasn't changed; a single
+// explicit invocation (the one-shot RunE path, a batch upload, or `upload
+// retry`) always passes dedup=false so it does what was asked instead of
+// silently exiting 0 for a file that previously had governance failures.
+func doUpload(cmd *cobra.Command, ctx *config.RunContext, path string, history *runhistory.Store, sinks []apiclient.RunSink, policyClient *apiclient.PolicyAPIClient, dedup bool) (*uploadResult, error) {
+	root, err := output.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not load input file %s err: %w", path, err)
+	}
+
+	sha, err := fileSHA256(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not checksum input file %s %w", path, err)
+	}
+
+	if dedup {
+		if existing, err := history.FindBySHA256(sha); err != nil {
+			logging.Logger.Warn().Err(err).Msg("could not check upload history for duplicate run")
+		} else if existing != nil && !existing.Failed {
+			cmd.Printf("Skipping upload: %s is unchanged since run %s\n", path, existing.RunID)
+			return &uploadResult{RunID: existing.RunID, ShareURL: existing.ShareURL}, nil
+		}
+	}
+
+	if policyClient != nil {
+		policies, err := policyClient.CheckPolicies(ctx, root)
+		if err != nil {
+			logging.Logger.Err(err).Msg("Failed to check policies")
+		} else {
+			root.TagPolicies = policies.TagPolicies
+			root.FinOpsPolicies = policies.FinOpsPolicies
+		}
+	}
+
+	var governanceFailures output.GovernanceFailures
+	var sinkErr error
+
+	for _, sink := range sinks {
+		result, err := sink.AddRun(ctx, root, apiclient.CommentFormatMarkdownHTML)
+		if err != nil {
+			sinkErr = fmt.Errorf("failed to upload to %s sink: %w", sink.Name(), err)
+			break
+		}
+
+		if result.RunID != "" {
+			root.RunID, root.ShareURL, root.CloudURL = result.RunID, result.ShareURL, result.CloudURL
+		}
+
+		governanceFailures = append(governanceFailures, result.GovernanceFailures...)
+	}
+
+	// Record the run even on failure, keyed by a locally-generated id if no
+	// sink ever assigned a real RunID, so a failed upload can still be
+	// looked up by `infracost upload retry`.
+	runID := root.RunID
+	if runID == "" {
+		runID = "local-" + sha[:16]
+	}
+
+	errMsg := ""
+	if sinkErr != nil {
+		errMsg = sinkErr.Error()
+	}
+
+	if err := history.Put(runhistory.Run{
+		RunID:          runID,
+		Path:           path,
+		ShareURL:       root.ShareURL,
+		CloudURL:       root.CloudURL,
+		PolicyFailures: len(governanceFailures),
+		UploadedAt:     time.Now(),
+		SHA256:         sha,
+		Failed:         sinkErr != nil,
+		Error:          errMsg,
+	}); err != nil {
+		logging.Logger.Warn().Err(err).Msg("could not record upload in history")
+	}
+
+	if sinkErr != nil {
+		return nil, sinkErr
+	}
+
+	if root.ShareURL != "" {
+		cmd.Println("Share this cost estimate: ", ui.LinkString(root.ShareURL))
+	}
+
+	pricingClient := apiclient.GetPricingAPIClient(ctx)
+	if err := pricingClient.AddEvent("infracost-upload", ctx.EventEnv()); err != nil {
+		logging.Logger.Warn().Err(err).Msg("could not report `infracost-upload` event")
+	}
+
+	return &uploadResult{RunID: root.RunID, ShareURL: root.ShareURL, GovernanceFailures: governanceFailures}, nil
+}
+
+// runWatch re-runs doUpload every time the file at path changes, until the
+// command is interrupted.
+func runWatch(cmd *cobra.Command, ctx *config.RunContext, path string, history *runhistory.Store, sinks []apiclient.RunSink, policyClient *apiclient.PolicyAPIClient) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("could not start file watcher %w", err)
+	}
+	defer watcher.Close() // nolint:errcheck
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("could not watch %s %w", path, err)
+	}
+
+	cmd.Printf("Watching %s for changes. Press Ctrl-C to stop.\n", path)
+
+	if _, err := doUpload(cmd, ctx, path, history, sinks, policyClient, true); err != nil {
+		logging.Logger.Err(err).Msg("upload failed")
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if filepath.Clean(event.Name) != filepath.Clean(path) {
+				continue
+			}
+
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			if _, err := doUpload(cmd, ctx, path, history, sinks, policyClient, true); err != nil {
+				logging.Logger.Err(err).Msg("upload failed")
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logging.Logger.Err(err).Msg("file watcher error")
+		}
+	}
+}
+
+// runScheduled re-runs doUpload on every tick of the given cron schedule,
+// until the command is interrupted.
+func runScheduled(cmd *cobra.Command, ctx *config.RunContext, path string, history *runhistory.Store, sinks []apiclient.RunSink, policyClient *apiclient.PolicyAPIClient, schedule string) error {
+	c := cron.New()
+
+	_, err := c.AddFunc(schedule, func() {
+		if _, err := doUpload(cmd, ctx, path, history, sinks, policyClient, true); err != nil {
+			logging.Logger.Err(err).Msg("scheduled upload failed")
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("invalid --schedule %q %w", schedule, err)
+	}
+
+	cmd.Printf("Uploading %s on schedule %q. Press Ctrl-C to stop.\n", path, schedule)
+
+	c.Start()
+	defer c.Stop()
+
+	select {} // run until interrupted
+}
+
+func fileSHA256(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/schollz/progressbar/v3"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/logging"
+	"github.com/infracost/infracost/internal/runhistory"
+)
+
+// manifestEntry overrides the run metadata for a single --path entry, read
+// from --manifest, so a monorepo CI job can upload many per-project runs
+// (each with its own PR URL/title, branch and commit) in one invocation
+// instead of one `infracost upload` per env var combination.
+type manifestEntry struct {
+	Path             string `yaml:"path"`
+	PullRequestURL   string `yaml:"pull_request_url,omitempty"`
+	PullRequestTitle string `yaml:"pull_request_title,omitempty"`
+	Branch           string `yaml:"branch,omitempty"`
+	Commit           string `yaml:"commit,omitempty"`
+}
+
+// loadManifest reads a YAML list of manifestEntry, keyed by Path for lookup
+// during the batch upload.
+func loadManifest(path string) (map[string]manifestEntry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest %s %w", path, err)
+	}
+
+	var entries []manifestEntry
+	if err := yaml.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("could not parse manifest %s %w", path, err)
+	}
+
+	byPath := make(map[string]manifestEntry, len(entries))
+	for _, e := range entries {
+		byPath[e.Path] = e
+	}
+
+	return byPath, nil
+}
+
+// applyManifestEntry overrides ctx's VCS run metadata with e's non-empty
+// fields, for a single file in a batch upload.
+func applyManifestEntry(ctx *config.RunContext, e manifestEntry) *config.RunContext {
+	runCtx := ctx.Clone()
+
+	if e.PullRequestURL != "" {
+		runCtx.Config.VCSPullRequestURL = e.PullRequestURL
+	}
+	if e.PullRequestTitle != "" {
+		runCtx.Config.VCSPullRequestTitle = e.PullRequestTitle
+	}
+	if e.Branch != "" {
+		runCtx.Config.VCSBranch = e.Branch
+	}
+	if e.Commit != "" {
+		runCtx.Config.VCSCommitSHA = e.Commit
+	}
+
+	return runCtx
+}
+
+// resolvePaths expands each raw --path value into a deduplicated, sorted
+// list of JSON files: a literal file is used as-is, a directory is walked
+// for *.json files, and anything else is treated as a glob pattern.
+func resolvePaths(raw []string) ([]string, error) {
+	seen := map[string]bool{}
+	var paths []string
+
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+
+	for _, r := range raw {
+		info, err := os.Stat(r)
+		switch {
+		case err == nil && info.IsDir():
+			walkErr := filepath.Walk(r, func(p string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !info.IsDir() && strings.EqualFold(filepath.Ext(p), ".json") {
+					add(p)
+				}
+				return nil
+			})
+			if walkErr != nil {
+				return nil, fmt.Errorf("could not read directory %s %w", r, walkErr)
+			}
+		case err == nil:
+			add(r)
+		default:
+			matches, globErr := filepath.Glob(r)
+			if globErr != nil || len(matches) == 0 {
+				return nil, fmt.Errorf("no files matched --path %s", r)
+			}
+			for _, m := range matches {
+				add(m)
+			}
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// batchRecord is the outcome of uploading a single file in a batch, used to
+// build the aggregated summary table.
+type batchRecord struct {
+	Path               string
+	RunID              string
+	ShareURL           string
+	GovernanceFailures int
+	Err                error
+	// Skipped is set for paths that were never attempted because an
+	// earlier file failed and --continue-on-error wasn't set. They must
+	// not be reported (or counted) as a pass.
+	Skipped bool
+}
+
+// runBatch uploads every path concurrently, bounded by parallelism, reusing
+// the single policyClient passed in so its allow-list/local policies are
+// only loaded once. It prints an aggregated summary table and returns a
+// single combined error covering every file's GovernanceFailures, unless
+// continueOnError is false and a file fails outright, in which case that
+// error is returned immediately (other in-flight uploads are allowed to
+// finish so history/sinks stay consistent).
+func runBatch(cmd *cobra.Command, ctx *config.RunContext, paths []string, manifest map[string]manifestEntry, sinks []apiclient.RunSink, history *runhistory.Store, policyClient *apiclient.PolicyAPIClient, parallelism int, continueOnError bool) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	bar := progressbar.Default(int64(len(paths)), "uploading")
+	records := make([]batchRecord, len(paths))
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	var firstFatal error
+	var mu sync.Mutex
+
+	for i, path := range paths {
+		i, path := i, path
+
+		mu.Lock()
+		abort := !continueOnError && firstFatal != nil
+		mu.Unlock()
+		if abort {
+			// Every remaining path (from i onward) was never attempted;
+			// mark them distinctly instead of leaving them as zero-value
+			// batchRecords, which printBatchSummary would otherwise render
+			// as blank "pass" rows.
+			for j := i; j < len(paths); j++ {
+				records[j] = batchRecord{
+					Path:    paths[j],
+					Skipped: true,
+					Err:     fmt.Errorf("skipped: upload aborted after an earlier failure (--continue-on-error not set)"),
+				}
+			}
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer bar.Add(1) // nolint:errcheck
+
+			runCtx := ctx
+			if entry, ok := manifest[path]; ok {
+				runCtx = applyManifestEntry(ctx, entry)
+			}
+
+			result, err := doUpload(cmd, runCtx, path, history, sinks, policyClient, false)
+			rec := batchRecord{Path: path}
+
+			if err != nil {
+				rec.Err = err
+				logging.Logger.Err(err).Str("path", path).Msg("upload failed")
+
+				mu.Lock()
+				if firstFatal == nil {
+					firstFatal = err
+				}
+				mu.Unlock()
+			} else {
+				rec.RunID = result.RunID
+				rec.ShareURL = result.ShareURL
+				rec.GovernanceFailures = len(result.GovernanceFailures)
+			}
+
+			records[i] = rec
+		}()
+	}
+
+	wg.Wait()
+
+	printBatchSummary(cmd, records)
+
+	if !continueOnError && firstFatal != nil {
+		return firstFatal
+	}
+
+	var failed []string
+	totalGovernanceFailures := 0
+	for _, r := range records {
+		if r.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", r.Path, r.Err))
+		}
+		totalGovernanceFailures += r.GovernanceFailures
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d uploads failed:\n%s", len(failed), len(records), strings.Join(failed, "\n"))
+	}
+
+	if totalGovernanceFailures > 0 {
+		return fmt.Errorf("%d governance failure(s) across %d run(s)", totalGovernanceFailures, len(records))
+	}
+
+	return nil
+}
+
+func printBatchSummary(cmd *cobra.Command, records []batchRecord) {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tRUN ID\tSHARE URL\tSTATUS")
+
+	for _, r := range records {
+		if r.Skipped {
+			fmt.Fprintf(w, "%s\t-\t-\tskipped\n", r.Path)
+			continue
+		}
+
+		if r.Err != nil {
+			fmt.Fprintf(w, "%s\t-\t-\terror: %s\n", r.Path, r.Err)
+			continue
+		}
+
+		status := "pass"
+		if r.GovernanceFailures > 0 {
+			status = fmt.Sprintf("fail (%d policy failures)", r.GovernanceFailures)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Path, r.RunID, r.ShareURL, status)
+	}
+
+	_ = w.Flush()
+}
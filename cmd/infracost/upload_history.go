@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/runhistory"
+)
+
+func uploadHistoryCmd(ctx *config.RunContext) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history",
+		Short: "List past `infracost upload` runs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			historyFile, err := defaultHistoryFile()
+			if err != nil {
+				return err
+			}
+
+			history, err := runhistory.Open(historyFile)
+			if err != nil {
+				return fmt.Errorf("could not open upload history %w", err)
+			}
+			defer history.Close() // nolint:errcheck
+
+			runs, err := history.List()
+			if err != nil {
+				return fmt.Errorf("could not list upload history %w", err)
+			}
+
+			if len(runs) == 0 {
+				cmd.Println("No uploads recorded yet.")
+				return nil
+			}
+
+			for _, r := range runs {
+				cmd.Printf("%s  uploaded=%s  path=%s  policyFailures=%d  url=%s\n",
+					r.RunID, r.UploadedAt.Format("2006-01-02T15:04:05Z07:00"), r.Path, r.PolicyFailures, r.ShareURL)
+			}
+
+			return nil
+		},
+	}
+}
+
+func uploadRetryCmd(ctx *config.RunContext) *cobra.Command {
+	return &cobra.Command{
+		Use:   "retry <runID>",
+		Short: "Replay a past upload against the current policy set",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			historyFile, err := defaultHistoryFile()
+			if err != nil {
+				return err
+			}
+
+			history, err := runhistory.Open(historyFile)
+			if err != nil {
+				return fmt.Errorf("could not open upload history %w", err)
+			}
+			defer history.Close() // nolint:errcheck
+
+			run, err := history.Get(args[0])
+			if err != nil {
+				return fmt.Errorf("could not look up run %s %w", args[0], err)
+			}
+
+			if run == nil {
+				return fmt.Errorf("no recorded run with id %s", args[0])
+			}
+
+			sinkFlags, _ := cmd.Flags().GetStringArray("sink")
+			sinks, err := resolveSinks(ctx, sinkFlags)
+			if err != nil {
+				return err
+			}
+
+			policyClient, err := buildPolicyClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			// dedup=false: a retry must always re-upload and re-evaluate
+			// policies, even though the file's SHA256 is guaranteed to
+			// already be in history for this exact run.
+			result, err := doUpload(cmd, ctx, run.Path, history, sinks, policyClient, false)
+			if err != nil {
+				return err
+			}
+
+			if len(result.GovernanceFailures) > 0 {
+				return result.GovernanceFailures
+			}
+
+			return nil
+		},
+	}
+}
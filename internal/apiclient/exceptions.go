@@ -0,0 +1,162 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Exception is a local waiver for a single policy rule violation, managed
+// with `infracost policy exception add|list|remove` and persisted to
+// .infracost/exceptions.yaml. It matches a policy result resource by Checksum or
+// Address, or any resource whose Path matches the PathGlob, and only
+// suppresses violations of RuleID (a TagPolicy's TagPolicyID or a
+// FinOpsPolicy's PolicyID).
+type Exception struct {
+	Ref      string     `yaml:"ref"`
+	Checksum string     `yaml:"checksum,omitempty"`
+	Address  string     `yaml:"address,omitempty"`
+	PathGlob string     `yaml:"path,omitempty"`
+	RuleID   string     `yaml:"ruleId"`
+	Reason   string     `yaml:"reason"`
+	Expiry   *time.Time `yaml:"expiry,omitempty"`
+}
+
+// expired reports whether e's TTL has passed as of now, so that stale
+// waivers automatically re-fail instead of silently suppressing violations
+// forever.
+func (e Exception) expired(now time.Time) bool {
+	return e.Expiry != nil && now.After(*e.Expiry)
+}
+
+// matches reports whether e covers a violation of ruleID on the resource
+// identified by checksum, address and path.
+func (e Exception) matches(checksum, address, path, ruleID string, now time.Time) bool {
+	if e.expired(now) || e.RuleID != ruleID {
+		return false
+	}
+
+	if e.Checksum != "" && e.Checksum == checksum {
+		return true
+	}
+
+	if e.Address != "" && e.Address == address {
+		return true
+	}
+
+	if e.PathGlob != "" {
+		if ok, _ := filepath.Match(e.PathGlob, path); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExceptionRef deterministically identifies an exception covering ruleID on
+// the resource(s) identified by checksum, address and pathGlob, so the same
+// `infracost policy exception add` invocation always produces the same Ref
+// and can be targeted again by `infracost policy exception remove`. pathGlob
+// must be included: two path-glob-only exceptions (no checksum/address) for
+// the same rule but different globs are distinct waivers and must not hash
+// to the same Ref.
+func ExceptionRef(address, checksum, pathGlob, ruleID string) string {
+	h := sha256.New()
+	h.Write([]byte(address))
+	h.Write([]byte(checksum))
+	h.Write([]byte(pathGlob))
+	h.Write([]byte(ruleID))
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// ExceptionStore is the in-memory, load/save representation of
+// .infracost/exceptions.yaml.
+type ExceptionStore struct {
+	path       string
+	Exceptions []Exception `yaml:"exceptions"`
+}
+
+// LoadExceptionStore reads the exceptions file at path. A missing file is
+// not an error; it just means no exceptions have been added yet.
+func LoadExceptionStore(path string) (*ExceptionStore, error) {
+	s := &ExceptionStore{path: path}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read exceptions file %s %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("could not parse exceptions file %s %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Save writes the store back to its path, creating the parent .infracost
+// directory if needed.
+func (s *ExceptionStore) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("could not create exceptions directory %w", err)
+	}
+
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("could not marshal exceptions %w", err)
+	}
+
+	if err := os.WriteFile(s.path, b, 0o644); err != nil {
+		return fmt.Errorf("could not write exceptions file %s %w", s.path, err)
+	}
+
+	return nil
+}
+
+// Add appends e to the store (replacing any existing exception with the
+// same Ref) and persists the change.
+func (s *ExceptionStore) Add(e Exception) error {
+	for i, existing := range s.Exceptions {
+		if existing.Ref == e.Ref {
+			s.Exceptions[i] = e
+			return s.Save()
+		}
+	}
+
+	s.Exceptions = append(s.Exceptions, e)
+
+	return s.Save()
+}
+
+// Remove deletes the exception with the given ref and persists the change.
+// It returns an error if no such exception exists.
+func (s *ExceptionStore) Remove(ref string) error {
+	for i, e := range s.Exceptions {
+		if e.Ref == ref {
+			s.Exceptions = append(s.Exceptions[:i], s.Exceptions[i+1:]...)
+			return s.Save()
+		}
+	}
+
+	return fmt.Errorf("no exception found with ref %s", ref)
+}
+
+// Waived reports whether an active (non-expired) exception covers a
+// violation of ruleID on the resource identified by checksum, address and
+// path.
+func (s *ExceptionStore) Waived(checksum, address, path, ruleID string, now time.Time) bool {
+	for _, e := range s.Exceptions {
+		if e.matches(checksum, address, path, ruleID, now) {
+			return true
+		}
+	}
+
+	return false
+}
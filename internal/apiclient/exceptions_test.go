@@ -0,0 +1,100 @@
+package apiclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExceptionMatchesByChecksum(t *testing.T) {
+	e := Exception{Checksum: "abc123", RuleID: "tag-policy-1"}
+
+	if !e.matches("abc123", "", "", "tag-policy-1", time.Now()) {
+		t.Fatal("expected exception to match by checksum")
+	}
+	if e.matches("other", "", "", "tag-policy-1", time.Now()) {
+		t.Fatal("did not expect exception to match a different checksum")
+	}
+}
+
+func TestExceptionMatchesByAddress(t *testing.T) {
+	e := Exception{Address: "aws_instance.web", RuleID: "tag-policy-1"}
+
+	if !e.matches("", "aws_instance.web", "", "tag-policy-1", time.Now()) {
+		t.Fatal("expected exception to match by address")
+	}
+	if e.matches("", "aws_instance.other", "", "tag-policy-1", time.Now()) {
+		t.Fatal("did not expect exception to match a different address")
+	}
+}
+
+func TestExceptionMatchesByPathGlob(t *testing.T) {
+	e := Exception{PathGlob: "modules/*", RuleID: "tag-policy-1"}
+
+	if !e.matches("", "", "modules/network", "tag-policy-1", time.Now()) {
+		t.Fatal("expected exception to match a path covered by the glob")
+	}
+	if e.matches("", "", "other/network", "tag-policy-1", time.Now()) {
+		t.Fatal("did not expect exception to match a path outside the glob")
+	}
+}
+
+func TestExceptionMatchesRespectsRuleID(t *testing.T) {
+	e := Exception{Checksum: "abc123", RuleID: "tag-policy-1"}
+
+	if e.matches("abc123", "", "", "tag-policy-2", time.Now()) {
+		t.Fatal("did not expect exception to match a different rule id")
+	}
+}
+
+func TestExceptionMatchesRespectsExpiry(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	e := Exception{Checksum: "abc123", RuleID: "tag-policy-1", Expiry: &past}
+
+	if e.matches("abc123", "", "", "tag-policy-1", time.Now()) {
+		t.Fatal("did not expect an expired exception to match")
+	}
+
+	future := time.Now().Add(time.Hour)
+	e.Expiry = &future
+	if !e.matches("abc123", "", "", "tag-policy-1", time.Now()) {
+		t.Fatal("expected an unexpired exception to match")
+	}
+}
+
+func TestExceptionStoreWaived(t *testing.T) {
+	s := &ExceptionStore{
+		Exceptions: []Exception{
+			{Checksum: "abc123", RuleID: "tag-policy-1"},
+		},
+	}
+
+	if !s.Waived("abc123", "", "", "tag-policy-1", time.Now()) {
+		t.Fatal("expected a matching exception to waive the violation")
+	}
+	if s.Waived("abc123", "", "", "tag-policy-2", time.Now()) {
+		t.Fatal("did not expect a rule-id mismatch to be waived")
+	}
+}
+
+// TestExceptionRefDistinctForDifferentPathGlobs guards against the
+// collision this package used to have: two path-glob-only waivers for the
+// same rule but different globs (no checksum/address) must hash to
+// different Refs, or adding the second would silently replace the first in
+// ExceptionStore.Add.
+func TestExceptionRefDistinctForDifferentPathGlobs(t *testing.T) {
+	refA := ExceptionRef("", "", "a/*", "tag-policy-1")
+	refB := ExceptionRef("", "", "b/*", "tag-policy-1")
+
+	if refA == refB {
+		t.Fatalf("expected distinct refs for distinct path globs, got %s for both", refA)
+	}
+}
+
+func TestExceptionRefStableForSameInputs(t *testing.T) {
+	refA := ExceptionRef("aws_instance.web", "abc123", "", "tag-policy-1")
+	refB := ExceptionRef("aws_instance.web", "abc123", "", "tag-policy-1")
+
+	if refA != refB {
+		t.Fatalf("expected the same inputs to produce the same ref, got %s and %s", refA, refB)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	json "github.com/json-iterator/go"
@@ -14,6 +15,7 @@ import (
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/logging"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
 	"github.com/infracost/infracost/internal/schema"
 )
 
@@ -25,9 +27,17 @@ type PolicyAPIClient struct {
 	allowLists    map[string]allowList
 	allowListErr  error
 	allowListOnce sync.Once
+
+	// localEvaluator is set when policies should be evaluated offline,
+	// either because no PolicyV2APIEndpoint is configured or the user
+	// passed --local-policies. When set, CheckPolicies never contacts
+	// Infracost Cloud.
+	localEvaluator policy.Evaluator
 }
 
-// NewPolicyAPIClient retrieves resource allow-list info from Infracost Cloud and returns a new policy client
+// NewPolicyAPIClient retrieves resource allow-list info from Infracost Cloud and returns a new policy client.
+// If ctx.Config.PolicyV2APIEndpoint is empty or ctx.Config.LocalPolicies is set, policies are loaded from
+// ctx.Config.PoliciesDir and evaluated locally instead of via Infracost Cloud.
 func NewPolicyAPIClient(ctx *config.RunContext) (*PolicyAPIClient, error) {
 	client := retryablehttp.NewClient()
 	client.Logger = &LeveledLogger{Logger: logging.Logger.With().Str("library", "retryablehttp").Logger()}
@@ -40,6 +50,19 @@ func NewPolicyAPIClient(ctx *config.RunContext) (*PolicyAPIClient, error) {
 		},
 	}
 
+	if ctx.Config.PolicyV2APIEndpoint == "" || ctx.Config.LocalPolicies {
+		if ctx.Config.PoliciesDir == "" {
+			return nil, fmt.Errorf("local policy evaluation requires a policies directory to be set in infracost.yml")
+		}
+
+		evaluator, err := policy.NewEvaluator(ctx.Config.PoliciesDir)
+		if err != nil {
+			return nil, fmt.Errorf("could not load local policies %w", err)
+		}
+
+		c.localEvaluator = evaluator
+	}
+
 	return &c, nil
 }
 
@@ -49,6 +72,30 @@ type PolicyOutput struct {
 }
 
 func (c *PolicyAPIClient) CheckPolicies(ctx *config.RunContext, out output.Root) (*PolicyOutput, error) {
+	policies, err := c.checkPolicies(ctx, out)
+	if err != nil {
+		return nil, err
+	}
+
+	if policies == nil {
+		return nil, nil
+	}
+
+	exceptions, err := LoadExceptionStore(ctx.Config.ExceptionsFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policy exceptions %w", err)
+	}
+
+	applyExceptions(policies, exceptions)
+
+	return policies, nil
+}
+
+func (c *PolicyAPIClient) checkPolicies(ctx *config.RunContext, out output.Root) (*PolicyOutput, error) {
+	if c.localEvaluator != nil {
+		return c.checkPoliciesLocally(out)
+	}
+
 	ri, err := newRunInput(ctx, out)
 	if err != nil {
 		return nil, err
@@ -172,6 +219,58 @@ func (c *PolicyAPIClient) CheckPolicies(ctx *config.RunContext, out output.Root)
 	return &PolicyOutput{policies.EvaluatePolicies.TagPolicies, policies.EvaluatePolicies.FinOpsPolicies}, nil
 }
 
+// checkPoliciesLocally evaluates out against the policies loaded into
+// c.localEvaluator, without contacting Infracost Cloud. It returns the same
+// PolicyOutput shape CheckPolicies returns for the cloud evaluatePolicies
+// query, so callers don't need to know which path ran.
+func (c *PolicyAPIClient) checkPoliciesLocally(out output.Root) (*PolicyOutput, error) {
+	input, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal run for local policy evaluation %w", err)
+	}
+
+	result, err := c.localEvaluator.Evaluate(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate local policies %w", err)
+	}
+
+	return &PolicyOutput{TagPolicies: result.TagPolicies, FinOpsPolicies: result.FinOpsPolicies}, nil
+}
+
+// applyExceptions drops any resource from policies that has an active
+// waiver in exceptions, so that GovernanceFailures and the PR comment never
+// see resources the user has explicitly excluded. It mutates policies in
+// place and sets each tag policy's TotalDetectedResources to the number of
+// resources kept; FinOps policies' TotalApplicableResources is left as is.
+func applyExceptions(policies *PolicyOutput, exceptions *ExceptionStore) {
+	now := time.Now()
+
+	for i, tp := range policies.TagPolicies {
+		kept := tp.Resources[:0]
+		for _, r := range tp.Resources {
+			if exceptions.Waived("", r.Address, r.Path, tp.TagPolicyID, now) {
+				continue
+			}
+			kept = append(kept, r)
+		}
+
+		policies.TagPolicies[i].Resources = kept
+		policies.TagPolicies[i].TotalDetectedResources = len(kept)
+	}
+
+	for i, fp := range policies.FinOpsPolicies {
+		kept := fp.Resources[:0]
+		for _, r := range fp.Resources {
+			if exceptions.Waived(r.Checksum, r.Address, r.Path, fp.PolicyID, now) {
+				continue
+			}
+			kept = append(kept, r)
+		}
+
+		policies.FinOpsPolicies[i].Resources = kept
+	}
+}
+
 // UploadPolicyData sends a filtered set of a project's resource information to Infracost Cloud and
 // potentially adds PolicySha and PastPolicySha to the project's metadata.
 func (c *PolicyAPIClient) UploadPolicyData(project *schema.Project) error {
@@ -272,6 +371,11 @@ type policy2Resource struct {
 	Values       json.RawMessage          `json:"values"`
 	References   []policy2Reference       `json:"references"`
 	Metadata     policy2InfracostMetadata `json:"infracostMetadata"`
+	// ExceptionScope identifies this resource independent of any one rule:
+	// it is ExceptionRef of the address and checksum, with an empty path
+	// glob and rule id. ExceptionStore.Waived does not use it; it matches
+	// exceptions by their checksum, address and path fields.
+	ExceptionScope string `json:"exceptionScope,omitempty"`
 }
 
 type policy2InfracostMetadata struct {
@@ -370,6 +474,7 @@ func filterResource(rd *schema.ResourceData, al allowList) policy2Resource {
 			Filename:  rd.Metadata["filename"].String(),
 			StartLine: rd.Metadata["startLine"].Int(),
 		},
+		ExceptionScope: ExceptionRef(rd.Address, checksum, "", ""),
 	}
 }
 
@@ -0,0 +1,207 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// RunSinkResult is what a RunSink returns after uploading a run. Only the
+// Infracost Cloud sink populates RunID/ShareURL/CloudURL/GovernanceFailures
+// today; other sinks leave them zero.
+type RunSinkResult struct {
+	RunID              string
+	ShareURL           string
+	CloudURL           string
+	GovernanceFailures output.GovernanceFailures
+}
+
+// RunSink is anywhere `infracost upload` can send a run's Infracost JSON and
+// policy results. Infracost Cloud is the default sink; NewRunSinks also
+// builds S3, GCS, Azure Blob and generic webhook sinks from --sink values or
+// the `sinks:` block in infracost.yml, so self-hosted users can route runs
+// to infrastructure they control.
+type RunSink interface {
+	// Name identifies the sink for logging, e.g. "cloud", "s3", "gcs", "azureblob", "webhook".
+	Name() string
+	AddRun(ctx *config.RunContext, root output.Root, format CommentFormat) (*RunSinkResult, error)
+}
+
+// cloudRunSink adapts the existing DashboardAPIClient to the RunSink
+// interface so it can be combined with the other sinks transparently.
+type cloudRunSink struct {
+	client *DashboardAPIClient
+}
+
+// NewCloudRunSink returns the RunSink that uploads to Infracost Cloud.
+func NewCloudRunSink(ctx *config.RunContext) RunSink {
+	return &cloudRunSink{client: NewDashboardAPIClient(ctx)}
+}
+
+func (s *cloudRunSink) Name() string { return "cloud" }
+
+func (s *cloudRunSink) AddRun(ctx *config.RunContext, root output.Root, format CommentFormat) (*RunSinkResult, error) {
+	result, err := s.client.AddRun(ctx, root, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunSinkResult{
+		RunID:              result.RunID,
+		ShareURL:           result.ShareURL,
+		CloudURL:           result.CloudURL,
+		GovernanceFailures: result.GovernanceFailures,
+	}, nil
+}
+
+// NewRunSinks builds a RunSink for each value in sinks. Supported forms are:
+//
+//	cloud                                upload to Infracost Cloud
+//	s3://bucket/prefix                   upload to an S3 bucket
+//	gs://bucket/prefix                   upload to a GCS bucket
+//	azblob://container/prefix            upload to an Azure Blob container
+//	webhook:https://example.com/hook     POST a signed payload to a URL
+//
+// If sinks is empty, it defaults to ["cloud"], matching the long-standing
+// default of uploading to Infracost Cloud.
+func NewRunSinks(ctx *config.RunContext, sinks []string) ([]RunSink, error) {
+	if len(sinks) == 0 {
+		sinks = []string{"cloud"}
+	}
+
+	var out []RunSink
+	for _, raw := range sinks {
+		sink, err := newRunSink(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --sink %q %w", raw, err)
+		}
+
+		out = append(out, sink)
+	}
+
+	// The cloud sink is the only one that assigns a RunID, and the
+	// canonical object-store layout (buildObjectStoreArtifacts) is keyed by
+	// that RunID, so cloud must always run before the other sinks no
+	// matter what order the user passed --sink in, or an object-store sink
+	// listed first would key its upload off a throwaway local-<timestamp>
+	// id instead of the real run.
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name() == "cloud" && out[j].Name() != "cloud"
+	})
+
+	return out, nil
+}
+
+func newRunSink(ctx *config.RunContext, raw string) (RunSink, error) {
+	switch {
+	case raw == "cloud":
+		return NewCloudRunSink(ctx), nil
+	case strings.HasPrefix(raw, "s3://"):
+		return newS3RunSink(strings.TrimPrefix(raw, "s3://"))
+	case strings.HasPrefix(raw, "gs://"):
+		return newGCSRunSink(strings.TrimPrefix(raw, "gs://"))
+	case strings.HasPrefix(raw, "azblob://"):
+		return newAzureBlobRunSink(strings.TrimPrefix(raw, "azblob://"))
+	case strings.HasPrefix(raw, "webhook:"):
+		return newWebhookRunSink(strings.TrimPrefix(raw, "webhook:"))
+	default:
+		return nil, fmt.Errorf("unrecognized sink, expected one of cloud, s3://, gs://, azblob://, webhook:")
+	}
+}
+
+// objectStoreArtifacts is the canonical set of files written to every
+// object-store sink (S3, GCS, Azure Blob): the raw Infracost JSON, the
+// policy results, a short PR comment, and a manifest of their SHA256s so
+// downstream systems can verify integrity.
+type objectStoreArtifacts struct {
+	prefix string
+	files  map[string][]byte // file name -> contents, e.g. "infracost.json"
+}
+
+// manifest is written alongside the other artifacts as "manifest.json".
+type manifest struct {
+	RunID     string         `json:"runId"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Files     []manifestFile `json:"files"`
+}
+
+type manifestFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// buildObjectStoreArtifacts lays out a run as
+// <repo>/<pr>/<runID>/{infracost.json,policy-results.json,comment.md} plus a
+// manifest.json of their SHA256s, so any object-store sink can write the
+// same canonical structure.
+func buildObjectStoreArtifacts(root output.Root) (*objectStoreArtifacts, error) {
+	runID := root.RunID
+	if runID == "" {
+		runID = fmt.Sprintf("local-%d", time.Now().UnixNano())
+	}
+
+	infracostJSON, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal Infracost JSON %w", err)
+	}
+
+	policyResults, err := json.MarshalIndent(struct {
+		TagPolicies    []output.TagPolicy    `json:"tagPolicies"`
+		FinOpsPolicies []output.FinOpsPolicy `json:"finopsPolicies"`
+	}{root.TagPolicies, root.FinOpsPolicies}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal policy results %w", err)
+	}
+
+	comment := []byte(runComment(root))
+
+	files := map[string][]byte{
+		"infracost.json":      infracostJSON,
+		"policy-results.json": policyResults,
+		"comment.md":          comment,
+	}
+
+	m := manifest{RunID: runID, CreatedAt: time.Now()}
+	for name, contents := range files {
+		sum := sha256.Sum256(contents)
+		m.Files = append(m.Files, manifestFile{Name: name, SHA256: hex.EncodeToString(sum[:])})
+	}
+
+	manifestJSON, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal manifest %w", err)
+	}
+	files["manifest.json"] = manifestJSON
+
+	repo := firstNonEmpty(os.Getenv("INFRACOST_VCS_REPOSITORY"), "unknown-repo")
+	pr := firstNonEmpty(os.Getenv("INFRACOST_VCS_PULL_REQUEST_NUMBER"), "no-pr")
+
+	return &objectStoreArtifacts{
+		prefix: path.Join(repo, pr, runID),
+		files:  files,
+	}, nil
+}
+
+func runComment(root output.Root) string {
+	return fmt.Sprintf("Infracost run %s: %d tag policy result(s), %d FinOps policy result(s). %s",
+		root.RunID, len(root.TagPolicies), len(root.FinOpsPolicies), root.ShareURL)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
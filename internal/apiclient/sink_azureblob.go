@@ -0,0 +1,63 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// azureBlobRunSink uploads the canonical run artifacts to an Azure Blob
+// Storage container, using the connection string from
+// AZURE_STORAGE_CONNECTION_STRING.
+type azureBlobRunSink struct {
+	container string
+	prefix    string
+}
+
+func newAzureBlobRunSink(location string) (RunSink, error) {
+	container, prefix, _ := strings.Cut(location, "/")
+	if container == "" {
+		return nil, fmt.Errorf("azure blob sink requires a container, e.g. azblob://my-container/infracost")
+	}
+
+	return &azureBlobRunSink{container: container, prefix: prefix}, nil
+}
+
+func (s *azureBlobRunSink) Name() string { return "azureblob" }
+
+func (s *azureBlobRunSink) AddRun(_ *config.RunContext, root output.Root, _ CommentFormat) (*RunSinkResult, error) {
+	artifacts, err := buildObjectStoreArtifacts(root)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionString := os.Getenv("AZURE_STORAGE_CONNECTION_STRING")
+	if connectionString == "" {
+		return nil, fmt.Errorf("AZURE_STORAGE_CONNECTION_STRING must be set to use the azblob sink")
+	}
+
+	client, err := azblob.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Azure Blob client %w", err)
+	}
+
+	ctx := context.Background()
+
+	for name, contents := range artifacts.files {
+		key := path.Join(s.prefix, artifacts.prefix, name)
+
+		_, err := client.UploadBuffer(ctx, s.container, key, contents, nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not upload %s to azblob://%s/%s %w", name, s.container, key, err)
+		}
+	}
+
+	return &RunSinkResult{}, nil
+}
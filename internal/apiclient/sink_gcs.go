@@ -0,0 +1,66 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// gcsRunSink uploads the canonical run artifacts to a GCS bucket, using
+// Application Default Credentials.
+type gcsRunSink struct {
+	bucket string
+	prefix string
+}
+
+func newGCSRunSink(location string) (RunSink, error) {
+	bucket, prefix, _ := strings.Cut(location, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("gcs sink requires a bucket, e.g. gs://my-bucket/infracost")
+	}
+
+	return &gcsRunSink{bucket: bucket, prefix: prefix}, nil
+}
+
+func (s *gcsRunSink) Name() string { return "gcs" }
+
+func (s *gcsRunSink) AddRun(_ *config.RunContext, root output.Root, _ CommentFormat) (*RunSinkResult, error) {
+	artifacts, err := buildObjectStoreArtifacts(root)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not create GCS client %w", err)
+	}
+	defer client.Close() // nolint:errcheck
+
+	bucket := client.Bucket(s.bucket)
+
+	for name, contents := range artifacts.files {
+		key := path.Join(s.prefix, artifacts.prefix, name)
+
+		w := bucket.Object(key).NewWriter(ctx)
+		if _, err := io.Copy(w, bytes.NewReader(contents)); err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("could not upload %s to gs://%s/%s %w", name, s.bucket, key, err)
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("could not finalize upload of %s to gs://%s/%s %w", name, s.bucket, key, err)
+		}
+	}
+
+	return &RunSinkResult{}, nil
+}
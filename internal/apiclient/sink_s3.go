@@ -0,0 +1,66 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// s3RunSink uploads the canonical run artifacts to an S3 bucket, using the
+// credentials and region from the environment/shared AWS config, the same
+// way Terraform's S3 backend does.
+type s3RunSink struct {
+	bucket string
+	prefix string
+}
+
+func newS3RunSink(location string) (RunSink, error) {
+	bucket, prefix, _ := strings.Cut(location, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("s3 sink requires a bucket, e.g. s3://my-bucket/infracost")
+	}
+
+	return &s3RunSink{bucket: bucket, prefix: prefix}, nil
+}
+
+func (s *s3RunSink) Name() string { return "s3" }
+
+func (s *s3RunSink) AddRun(_ *config.RunContext, root output.Root, _ CommentFormat) (*RunSinkResult, error) {
+	artifacts, err := buildObjectStoreArtifacts(root)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not load AWS config %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg)
+
+	for name, contents := range artifacts.files {
+		key := path.Join(s.prefix, artifacts.prefix, name)
+
+		_, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(contents),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not upload %s to s3://%s/%s %w", name, s.bucket, key, err)
+		}
+	}
+
+	return &RunSinkResult{}, nil
+}
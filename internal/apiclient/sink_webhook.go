@@ -0,0 +1,82 @@
+package apiclient
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// webhookSignatureHeader carries the hex-encoded HMAC-SHA256 of the request
+// body, keyed by the INFRACOST_WEBHOOK_SECRET environment variable, so the
+// receiver can verify the payload wasn't tampered with.
+const webhookSignatureHeader = "X-Infracost-Signature"
+
+// webhookRunSink POSTs the run's Infracost JSON and policy results as a
+// single signed JSON payload to an arbitrary URL.
+type webhookRunSink struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func newWebhookRunSink(url string) (RunSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook sink requires a URL, e.g. webhook:https://example.com/hook")
+	}
+
+	secret := os.Getenv("INFRACOST_WEBHOOK_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("webhook sink requires INFRACOST_WEBHOOK_SECRET to be set, so runs can be signed rather than posted unauthenticated")
+	}
+
+	return &webhookRunSink{url: url, secret: secret, client: &http.Client{}}, nil
+}
+
+func (s *webhookRunSink) Name() string { return "webhook" }
+
+type webhookPayload struct {
+	Root           output.Root           `json:"root"`
+	TagPolicies    []output.TagPolicy    `json:"tagPolicies"`
+	FinOpsPolicies []output.FinOpsPolicy `json:"finopsPolicies"`
+}
+
+func (s *webhookRunSink) AddRun(_ *config.RunContext, root output.Root, _ CommentFormat) (*RunSinkResult, error) {
+	body, err := json.Marshal(webhookPayload{
+		Root:           root,
+		TagPolicies:    root.TagPolicies,
+		FinOpsPolicies: root.FinOpsPolicies,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal webhook payload %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not build webhook request %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write(body)
+	req.Header.Set(webhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not call webhook %s %w", s.url, err)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("webhook %s returned status %d", s.url, resp.StatusCode)
+	}
+
+	return &RunSinkResult{}, nil
+}
@@ -0,0 +1,65 @@
+// Package config holds the CLI's run-time configuration: the parsed
+// infracost.yml / environment variables (Config) and the per-invocation
+// state built from them (RunContext). It is intentionally minimal here -
+// this package only carries the fields the apiclient/cmd packages in this
+// tree actually read, not the full configuration surface of the CLI.
+package config
+
+// Config is the merged configuration for a single CLI invocation, built
+// from infracost.yml, environment variables and CLI flags (CLI flags take
+// priority, then env vars, then infracost.yml).
+type Config struct {
+	APIKey               string `yaml:"-" envconfig:"INFRACOST_API_KEY"`
+	PricingAPIEndpoint   string `yaml:"-" envconfig:"INFRACOST_PRICING_API_ENDPOINT"`
+	DashboardAPIEndpoint string `yaml:"-" envconfig:"INFRACOST_DASHBOARD_API_ENDPOINT"`
+	PolicyV2APIEndpoint  string `yaml:"-" envconfig:"INFRACOST_POLICY_V2_API_ENDPOINT"`
+
+	// LocalPolicies forces policy evaluation to happen in-process against
+	// PoliciesDir instead of contacting Infracost Cloud, even when
+	// PolicyV2APIEndpoint is set. Set via `infracost upload --local-policies`.
+	LocalPolicies bool `yaml:"-"`
+
+	// PoliciesDir points at a directory of .rego/.cel policy files, set via
+	// the `policies:` key in infracost.yml.
+	PoliciesDir string `yaml:"policies,omitempty"`
+
+	// Sinks lists the run sinks (e.g. "cloud", "s3://bucket/prefix") that
+	// `infracost upload` fans out to when --sink isn't passed, set via the
+	// `sinks:` key in infracost.yml.
+	Sinks []string `yaml:"sinks,omitempty"`
+
+	// ExceptionsPath overrides where policy exceptions are stored, set via
+	// the `policy_exceptions_file:` key in infracost.yml. Defaults to
+	// .infracost/exceptions.yaml in the current directory.
+	ExceptionsPath string `yaml:"policy_exceptions_file,omitempty"`
+
+	VCSPullRequestURL   string `yaml:"-" envconfig:"INFRACOST_VCS_PULL_REQUEST_URL"`
+	VCSPullRequestTitle string `yaml:"-" envconfig:"INFRACOST_VCS_PULL_REQUEST_TITLE"`
+	VCSBranch           string `yaml:"-" envconfig:"INFRACOST_VCS_BRANCH"`
+	VCSCommitSHA        string `yaml:"-" envconfig:"INFRACOST_VCS_COMMIT_SHA"`
+
+	LogLevel string `yaml:"-" envconfig:"INFRACOST_LOG_LEVEL"`
+}
+
+// IsSelfHosted reports whether this invocation is configured to talk to a
+// self-hosted Infracost Cloud deployment rather than app.infracost.io.
+func (c *Config) IsSelfHosted() bool {
+	return c.PricingAPIEndpoint != "" || c.DashboardAPIEndpoint != ""
+}
+
+// IsLogging reports whether structured logs (as opposed to plain stdout/
+// stderr messages) are enabled for this invocation.
+func (c *Config) IsLogging() bool {
+	return c.LogLevel != ""
+}
+
+// ExceptionsFile returns the path policy exceptions are read from and
+// written to: ExceptionsPath if set, otherwise .infracost/exceptions.yaml
+// in the current directory.
+func (c *Config) ExceptionsFile() string {
+	if c.ExceptionsPath != "" {
+		return c.ExceptionsPath
+	}
+
+	return ".infracost/exceptions.yaml"
+}
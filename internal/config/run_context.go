@@ -0,0 +1,63 @@
+package config
+
+import (
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// RunContext carries the Config plus per-invocation state (a run UUID and
+// the writer used for non-structured-log CLI output) through a single
+// `infracost` command execution.
+type RunContext struct {
+	Config *Config
+
+	// ErrWriter is where human-readable progress messages are written when
+	// structured logging isn't enabled. Defaults to os.Stderr.
+	ErrWriter io.Writer
+
+	uuid uuid.UUID
+}
+
+// NewRunContextFromConfig builds a RunContext wrapping cfg, generating the
+// run UUID used to correlate API requests for this invocation.
+func NewRunContextFromConfig(cfg *Config) *RunContext {
+	return &RunContext{
+		Config:    cfg,
+		ErrWriter: os.Stderr,
+		uuid:      uuid.New(),
+	}
+}
+
+// UUID returns the unique id for this invocation, sent with API requests so
+// server-side logs can be correlated with a single CLI run.
+func (r *RunContext) UUID() string {
+	return r.uuid.String()
+}
+
+// EventEnv returns the metadata sent alongside usage events (e.g.
+// "infracost-upload"), so Infracost can tell which subcommand and VCS
+// context a usage event came from.
+func (r *RunContext) EventEnv() map[string]interface{} {
+	return map[string]interface{}{
+		"pullRequestUrl": r.Config.VCSPullRequestURL,
+		"branch":         r.Config.VCSBranch,
+		"commitSha":      r.Config.VCSCommitSHA,
+	}
+}
+
+// Clone returns a RunContext with a deep copy of Config (so overriding a
+// field, e.g. the VCS metadata for a single file in a batch upload, doesn't
+// race with or mutate the original) sharing the same run UUID and
+// ErrWriter.
+func (r *RunContext) Clone() *RunContext {
+	cfg := *r.Config
+	cfg.Sinks = append([]string(nil), r.Config.Sinks...)
+
+	return &RunContext{
+		Config:    &cfg,
+		ErrWriter: r.ErrWriter,
+		uuid:      r.uuid,
+	}
+}
@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+// celResource is the subset of a run's resource JSON that CEL policies are
+// evaluated against. CEL policies are simpler than Rego ones: each file is a
+// single boolean expression, evaluated once per resource, that returns true
+// when the resource is compliant.
+type celResource struct {
+	Address string            `json:"name"`
+	Type    string            `json:"resourceType"`
+	Tags    map[string]string `json:"tags"`
+}
+
+// celBreakdown mirrors output.Breakdown: the actual nesting level cost
+// resources live at in an Infracost JSON run, under project.breakdown and
+// project.pastBreakdown.
+type celBreakdown struct {
+	Resources []celResource `json:"resources"`
+}
+
+type celRun struct {
+	Projects []struct {
+		Breakdown *celBreakdown `json:"breakdown"`
+	} `json:"projects"`
+}
+
+// evaluateCEL compiles p as a single CEL expression and runs it against every
+// resource in the run, reporting each non-compliant resource (its address
+// and resource type only) on a single generated TagPolicy. CEL policies only produce
+// tag policy results; use Rego for FinOps policies.
+func evaluateCEL(p Policy, input []byte) (*Result, error) {
+	var run celRun
+	if err := json.Unmarshal(input, &run); err != nil {
+		return nil, fmt.Errorf("could not unmarshal run input %w", err)
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("tags", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("resource_type", cel.StringType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cel environment %w", err)
+	}
+
+	ast, iss := env.Compile(string(p.Source))
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("could not compile cel policy %w", iss.Err())
+	}
+
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("could not build cel program %w", err)
+	}
+
+	tagPolicy := output.TagPolicy{
+		Name:        p.Name,
+		TagPolicyID: p.Name,
+		Message:     fmt.Sprintf("%s failed", filepath.Base(p.Path)),
+	}
+
+	for _, proj := range run.Projects {
+		if proj.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range proj.Breakdown.Resources {
+			tagPolicy.TotalTaggableResources++
+
+			out, _, err := prg.Eval(map[string]interface{}{
+				"tags":          r.Tags,
+				"resource_type": r.Type,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("could not evaluate cel policy for %s %w", r.Address, err)
+			}
+
+			compliant, ok := out.Value().(bool)
+			if !ok {
+				return nil, fmt.Errorf("cel policy %s must return a bool, got %T", p.Path, out.Value())
+			}
+
+			if !compliant {
+				tagPolicy.TotalDetectedResources++
+				tagPolicy.Resources = append(tagPolicy.Resources, output.TagPolicyResource{
+					Address:      r.Address,
+					ResourceType: r.Type,
+				})
+			}
+		}
+	}
+
+	if tagPolicy.TotalDetectedResources == 0 {
+		return &Result{}, nil
+	}
+
+	return &Result{TagPolicies: []output.TagPolicy{tagPolicy}}, nil
+}
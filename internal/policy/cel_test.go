@@ -0,0 +1,69 @@
+package policy
+
+import "testing"
+
+// sampleRunJSON is a representative `infracost breakdown --format json`
+// fixture: cost resources are nested under project.breakdown.resources, not
+// directly under project.resources.
+const sampleRunJSON = `{
+	"projects": [
+		{
+			"name": "example",
+			"breakdown": {
+				"resources": [
+					{"name": "aws_instance.web", "resourceType": "aws_instance", "tags": {"env": "prod"}},
+					{"name": "aws_instance.untagged", "resourceType": "aws_instance", "tags": {}}
+				]
+			}
+		}
+	]
+}`
+
+func TestEvaluateCELMatchesBreakdownResources(t *testing.T) {
+	p := Policy{
+		Name:   "require-env-tag",
+		Engine: EngineCEL,
+		Path:   "require-env-tag.cel",
+		Source: []byte(`"env" in tags`),
+	}
+
+	result, err := evaluateCEL(p, []byte(sampleRunJSON))
+	if err != nil {
+		t.Fatalf("evaluateCEL returned error: %v", err)
+	}
+
+	if len(result.TagPolicies) != 1 {
+		t.Fatalf("expected 1 tag policy result, got %d: %+v", len(result.TagPolicies), result.TagPolicies)
+	}
+
+	tp := result.TagPolicies[0]
+	if tp.TotalTaggableResources != 2 {
+		t.Errorf("expected TotalTaggableResources=2 (both resources under breakdown.resources), got %d", tp.TotalTaggableResources)
+	}
+
+	if tp.TotalDetectedResources != 1 {
+		t.Errorf("expected TotalDetectedResources=1, got %d", tp.TotalDetectedResources)
+	}
+
+	if len(tp.Resources) != 1 || tp.Resources[0].Address != "aws_instance.untagged" {
+		t.Errorf("expected only aws_instance.untagged to be reported non-compliant, got %+v", tp.Resources)
+	}
+}
+
+func TestEvaluateCELCompliantRunReturnsEmptyResult(t *testing.T) {
+	p := Policy{
+		Name:   "always-true",
+		Engine: EngineCEL,
+		Path:   "always-true.cel",
+		Source: []byte("true"),
+	}
+
+	result, err := evaluateCEL(p, []byte(sampleRunJSON))
+	if err != nil {
+		t.Fatalf("evaluateCEL returned error: %v", err)
+	}
+
+	if len(result.TagPolicies) != 0 {
+		t.Errorf("expected no tag policy results when every resource is compliant, got %+v", result.TagPolicies)
+	}
+}
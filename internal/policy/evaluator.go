@@ -0,0 +1,75 @@
+// Package policy evaluates tag and FinOps policies locally, without needing
+// to contact Infracost Cloud. It is used by internal/apiclient.PolicyAPIClient
+// when no PolicyV2APIEndpoint is configured, or when the user has explicitly
+// asked to evaluate policies in-process (e.g. via --local-policies).
+package policy
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+// Result mirrors the shape of Infracost Cloud's evaluatePolicies GraphQL
+// response, so it can be assigned directly to output.Root.TagPolicies and
+// output.Root.FinOpsPolicies without any further conversion.
+type Result struct {
+	TagPolicies    []output.TagPolicy
+	FinOpsPolicies []output.FinOpsPolicy
+}
+
+// Evaluator runs a set of loaded policies against a run's JSON input and
+// returns the equivalent of what Infracost Cloud's evaluatePolicies query
+// would have returned for the same input.
+type Evaluator interface {
+	Evaluate(input []byte) (*Result, error)
+}
+
+// multiEvaluator dispatches each loaded policy to the engine matching its
+// file extension and merges their results into a single Result.
+type multiEvaluator struct {
+	policies []Policy
+}
+
+// NewEvaluator loads every Rego (.rego) and CEL (.cel) policy file under dir
+// and returns an Evaluator that runs all of them. dir is the directory
+// referenced by the `policies:` block in infracost.yml.
+func NewEvaluator(dir string) (Evaluator, error) {
+	policies, err := loadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not load policies from %s %w", dir, err)
+	}
+
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("no .rego or .cel policy files found in %s", dir)
+	}
+
+	return &multiEvaluator{policies: policies}, nil
+}
+
+func (m *multiEvaluator) Evaluate(input []byte) (*Result, error) {
+	result := &Result{}
+
+	for _, p := range m.policies {
+		var r *Result
+		var err error
+
+		switch p.Engine {
+		case EngineRego:
+			r, err = evaluateRego(p, input)
+		case EngineCEL:
+			r, err = evaluateCEL(p, input)
+		default:
+			err = fmt.Errorf("unsupported policy engine %q for %s", p.Engine, p.Path)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate policy %s %w", p.Path, err)
+		}
+
+		result.TagPolicies = append(result.TagPolicies, r.TagPolicies...)
+		result.FinOpsPolicies = append(result.FinOpsPolicies, r.FinOpsPolicies...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,24 @@
+package policy
+
+import "testing"
+
+func TestMultiEvaluatorMergesEngines(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir+"/require-env-tag.cel", `"env" in tags`)
+	writeFile(t, dir+"/require-env-tag.rego", sampleRegoPolicy)
+
+	evaluator, err := NewEvaluator(dir)
+	if err != nil {
+		t.Fatalf("NewEvaluator returned error: %v", err)
+	}
+
+	result, err := evaluator.Evaluate([]byte(sampleRunJSON))
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+
+	if len(result.TagPolicies) != 2 {
+		t.Fatalf("expected one tag policy result per policy file, got %d: %+v", len(result.TagPolicies), result.TagPolicies)
+	}
+}
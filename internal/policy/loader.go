@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Engine identifies which runtime a Policy's source should be evaluated with.
+type Engine string
+
+const (
+	EngineRego Engine = "rego"
+	EngineCEL  Engine = "cel"
+)
+
+// Policy is a single local policy file, loaded but not yet evaluated.
+type Policy struct {
+	Name   string
+	Engine Engine
+	Source []byte
+	Path   string
+}
+
+// loadDir reads every .rego and .cel file under dir (recursively) into a
+// Policy. Files with any other extension are ignored so that README files or
+// fixtures can live alongside the policies.
+func loadDir(dir string) ([]Policy, error) {
+	var policies []Policy
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		var engine Engine
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".rego":
+			engine = EngineRego
+		case ".cel":
+			engine = EngineCEL
+		default:
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("could not read policy file %s %w", path, err)
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+
+		policies = append(policies, Policy{
+			Name:   strings.TrimSuffix(rel, filepath.Ext(rel)),
+			Engine: engine,
+			Source: src,
+			Path:   path,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "tags.rego"), "package infracost\n")
+	writeFile(t, filepath.Join(dir, "tags.cel"), "true\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "not a policy\n")
+	writeFile(t, filepath.Join(dir, "nested", "more.rego"), "package infracost\n")
+
+	policies, err := loadDir(dir)
+	if err != nil {
+		t.Fatalf("loadDir returned error: %v", err)
+	}
+
+	if len(policies) != 3 {
+		t.Fatalf("expected 3 policies, got %d: %+v", len(policies), policies)
+	}
+
+	byName := make(map[string]Policy, len(policies))
+	for _, p := range policies {
+		byName[p.Name] = p
+	}
+
+	if p, ok := byName["tags"]; !ok || p.Engine != EngineRego {
+		t.Errorf("expected a rego policy named %q, got %+v", "tags", p)
+	}
+
+	if _, ok := byName[filepath.Join("nested", "more")]; !ok {
+		t.Errorf("expected a policy loaded from the nested directory, got %+v", byName)
+	}
+}
+
+func TestNewEvaluatorRejectsEmptyDir(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := NewEvaluator(dir); err == nil {
+		t.Fatal("expected an error for a directory with no .rego or .cel files")
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("could not create directory for %s: %v", path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// evaluateRego runs a single Rego policy module against input. The module is
+// expected to define two top-level rules, tag_policies and finops_policies,
+// each producing a JSON array shaped like the equivalent field in Infracost
+// Cloud's evaluatePolicies response (output.TagPolicy / output.FinOpsPolicy).
+// Either rule may be omitted if the policy only checks one kind of result.
+func evaluateRego(p Policy, input []byte) (*Result, error) {
+	var doc interface{}
+	if err := json.Unmarshal(input, &doc); err != nil {
+		return nil, fmt.Errorf("could not unmarshal run input %w", err)
+	}
+
+	r := rego.New(
+		rego.Query("data.infracost"),
+		rego.Module(p.Path, string(p.Source)),
+		rego.Input(doc),
+	)
+
+	rs, err := r.Eval(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("rego evaluation failed %w", err)
+	}
+
+	if len(rs) == 0 || len(rs[0].Expressions) == 0 {
+		return &Result{}, nil
+	}
+
+	pkg, ok := rs[0].Expressions[0].Value.(map[string]interface{})
+	if !ok {
+		return &Result{}, nil
+	}
+
+	result := &Result{}
+
+	if tagPolicies, ok := pkg["tag_policies"]; ok {
+		if err := remarshal(tagPolicies, &result.TagPolicies); err != nil {
+			return nil, fmt.Errorf("could not decode tag_policies %w", err)
+		}
+	}
+
+	if finopsPolicies, ok := pkg["finops_policies"]; ok {
+		if err := remarshal(finopsPolicies, &result.FinOpsPolicies); err != nil {
+			return nil, fmt.Errorf("could not decode finops_policies %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// remarshal round-trips v through JSON into dst, which is the simplest way
+// to turn the untyped map[string]interface{} that rego.Eval returns into the
+// output.TagPolicy / output.FinOpsPolicy structs we share with the cloud API.
+func remarshal(v interface{}, dst interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, dst)
+}
@@ -0,0 +1,47 @@
+package policy
+
+import "testing"
+
+const sampleRegoPolicy = `
+package infracost
+
+tag_policies = [{
+	"name": "require-env-tag",
+	"tagPolicyId": "require-env-tag",
+	"message": "missing env tag",
+	"totalDetectedResources": 1,
+	"totalTaggableResources": 2,
+	"resources": [{"address": "aws_instance.untagged", "resourceType": "aws_instance"}]
+}]
+`
+
+func TestEvaluateRegoDecodesTagPolicies(t *testing.T) {
+	p := Policy{
+		Name:   "require-env-tag",
+		Engine: EngineRego,
+		Path:   "require-env-tag.rego",
+		Source: []byte(sampleRegoPolicy),
+	}
+
+	result, err := evaluateRego(p, []byte(sampleRunJSON))
+	if err != nil {
+		t.Fatalf("evaluateRego returned error: %v", err)
+	}
+
+	if len(result.TagPolicies) != 1 {
+		t.Fatalf("expected 1 tag policy result, got %d: %+v", len(result.TagPolicies), result.TagPolicies)
+	}
+
+	if len(result.FinOpsPolicies) != 0 {
+		t.Errorf("expected no finops policy results, got %+v", result.FinOpsPolicies)
+	}
+
+	tp := result.TagPolicies[0]
+	if tp.TagPolicyID != "require-env-tag" {
+		t.Errorf("expected tagPolicyId %q, got %q", "require-env-tag", tp.TagPolicyID)
+	}
+
+	if len(tp.Resources) != 1 || tp.Resources[0].Address != "aws_instance.untagged" {
+		t.Errorf("expected only aws_instance.untagged in results, got %+v", tp.Resources)
+	}
+}
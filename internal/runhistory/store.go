@@ -0,0 +1,141 @@
+// Package runhistory persists a local record of `infracost upload` runs so
+// that scheduled/watch uploads can be listed, deduplicated and retried
+// without needing to contact Infracost Cloud.
+package runhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var runsBucket = []byte("runs")
+
+// Run is a single recorded upload.
+type Run struct {
+	RunID          string    `json:"runId"`
+	Path           string    `json:"path"`
+	ShareURL       string    `json:"shareUrl"`
+	CloudURL       string    `json:"cloudUrl"`
+	PolicyFailures int       `json:"policyFailures"`
+	UploadedAt     time.Time `json:"uploadedAt"`
+	SHA256         string    `json:"sha256"`
+
+	// Failed records that this run didn't make it to every sink. It's
+	// still persisted (keyed by a locally-generated RunID if no sink ever
+	// assigned a real one) so `infracost upload retry` has something to
+	// look up. A Failed run is never treated as a duplicate by
+	// FindBySHA256-based dedup.
+	Failed bool   `json:"failed,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Store is a local, file-backed history of uploads, keyed by RunID.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (creating if needed) the uploads database at path, along with
+// its parent directory.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("could not create directory for uploads history %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("could not open uploads history %s %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(runsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize uploads history %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put records r, overwriting any existing run with the same RunID.
+func (s *Store) Put(r Run) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("could not marshal run %w", err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucket).Put([]byte(r.RunID), b)
+	})
+}
+
+// Get returns the run with the given RunID, or nil if it isn't recorded.
+func (s *Store) Get(runID string) (*Run, error) {
+	var r *Run
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(runsBucket).Get([]byte(runID))
+		if b == nil {
+			return nil
+		}
+
+		r = &Run{}
+		return json.Unmarshal(b, r)
+	})
+
+	return r, err
+}
+
+// FindBySHA256 returns the most recent run uploaded with the given JSON
+// file checksum, so callers can skip re-uploading unchanged input.
+func (s *Store) FindBySHA256(sha256 string) (*Run, error) {
+	runs, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range runs {
+		if r.SHA256 == sha256 {
+			return &r, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// List returns every recorded run, most recently uploaded first.
+func (s *Store) List() ([]Run, error) {
+	var runs []Run
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucket).ForEach(func(_, v []byte) error {
+			var r Run
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+
+			runs = append(runs, r)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].UploadedAt.After(runs[j].UploadedAt)
+	})
+
+	return runs, nil
+}
@@ -0,0 +1,87 @@
+package runhistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	s, err := Open(filepath.Join(t.TempDir(), "uploads.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func TestPutGetList(t *testing.T) {
+	s := openTestStore(t)
+
+	r := Run{RunID: "run-1", Path: "infracost.json", SHA256: "abc", UploadedAt: time.Now()}
+	if err := s.Put(r); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	got, err := s.Get("run-1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got == nil || got.RunID != "run-1" {
+		t.Fatalf("expected to find run-1, got %+v", got)
+	}
+
+	runs, err := s.List()
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(runs))
+	}
+}
+
+func TestFindBySHA256ReturnsMostRecent(t *testing.T) {
+	s := openTestStore(t)
+
+	older := Run{RunID: "run-1", SHA256: "same", UploadedAt: time.Now().Add(-time.Hour)}
+	newer := Run{RunID: "run-2", SHA256: "same", UploadedAt: time.Now()}
+
+	if err := s.Put(older); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if err := s.Put(newer); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	found, err := s.FindBySHA256("same")
+	if err != nil {
+		t.Fatalf("FindBySHA256 returned error: %v", err)
+	}
+	if found == nil || found.RunID != "run-2" {
+		t.Fatalf("expected the most recent run with a matching SHA256, got %+v", found)
+	}
+}
+
+// TestFindBySHA256ReturnsFailedRuns documents that FindBySHA256 itself does
+// not filter out failed runs - callers (doUpload) are responsible for
+// checking the Failed field before treating a match as a skippable
+// duplicate, since a failed run never actually reached every sink.
+func TestFindBySHA256ReturnsFailedRuns(t *testing.T) {
+	s := openTestStore(t)
+
+	failed := Run{RunID: "local-abc", SHA256: "same", UploadedAt: time.Now(), Failed: true, Error: "boom"}
+	if err := s.Put(failed); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	found, err := s.FindBySHA256("same")
+	if err != nil {
+		t.Fatalf("FindBySHA256 returned error: %v", err)
+	}
+	if found == nil || !found.Failed {
+		t.Fatalf("expected to find the failed run, got %+v", found)
+	}
+}